@@ -0,0 +1,84 @@
+package netbox
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/fbreckle/go-netbox/netbox/client/users"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNetboxToken() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetboxTokenRead,
+		Description: `:meta:subcategory:Authentication:Get information about an existing NetBox API token by its ID. This is useful for referencing a token (e.g. its \`user_id\` or \`expires\`) managed outside of this Terraform configuration, such as one rotated by [resource "netbox_token"](../resources/token).`,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"user_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Sensitive:   true,
+				Computed:    true,
+				Description: "Only populated if ALLOW_TOKEN_RETRIEVAL is enabled on the NetBox instance.",
+			},
+			"allowed_ips": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"write_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"last_used": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expires": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceNetboxTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+	id := int64(d.Get("id").(int))
+
+	params := users.NewUsersTokensReadParams().WithID(id)
+	res, err := api.Users.UsersTokensRead(params, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	token := res.GetPayload()
+
+	d.SetId(strconv.FormatInt(id, 10))
+	if token.User != nil {
+		d.Set("user_id", token.User.ID)
+	}
+	if token.Key != "" {
+		d.Set("key", token.Key)
+	}
+	d.Set("last_used", token.LastUsed)
+	if token.Expires != nil {
+		d.Set("expires", token.Expires.String())
+	}
+	d.Set("allowed_ips", token.AllowedIps)
+	d.Set("write_enabled", token.WriteEnabled)
+	d.Set("description", token.Description)
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package netbox
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ephemeralNetboxTokenPlaintext serves the full v2 token plaintext
+// (`nbt_<KEY>.<SECRET>`) captured at creation time by
+// resourceNetboxTokenCreate, without ever writing it to Terraform state.
+// NetBox itself never returns that plaintext again after creation, so Open
+// reads it from globalPlaintextCache (populated at create, consumed here)
+// rather than re-querying NetBox; it is therefore only usable in the same
+// provider process/apply that created the token, and only once. It is
+// served by a separate plugin-framework provider instance behind the mux
+// (see ProviderServer) with no configuration of its own, which is why it
+// reaches into a package-level cache instead of a configured
+// *providerState. It requires Terraform 1.10+.
+type ephemeralNetboxTokenPlaintext struct{}
+
+func newEphemeralNetboxTokenPlaintext() ephemeral.EphemeralResource {
+	return &ephemeralNetboxTokenPlaintext{}
+}
+
+func (e *ephemeralNetboxTokenPlaintext) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token_plaintext"
+}
+
+func (e *ephemeralNetboxTokenPlaintext) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the full v2 token plaintext (`nbt_<KEY>.<SECRET>`) produced by `resource \"netbox_token\"` on create, without persisting it to state.",
+		Attributes: map[string]schema.Attribute{
+			"token_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the `netbox_token` resource to read the plaintext from.",
+			},
+			"full_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Full v2 token plaintext. Empty if it has already been consumed since creation, or if the token was created in a different provider process/apply.",
+			},
+		},
+	}
+}
+
+type ephemeralNetboxTokenPlaintextModel struct {
+	TokenID types.String `tfsdk:"token_id"`
+	FullKey types.String `tfsdk:"full_key"`
+}
+
+func (e *ephemeralNetboxTokenPlaintext) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralNetboxTokenPlaintextModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.TokenID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid token_id", err.Error())
+		return
+	}
+
+	fullKey, _ := globalPlaintextCache.consume(id)
+
+	data.FullKey = types.StringValue(fullKey)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
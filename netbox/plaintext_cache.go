@@ -0,0 +1,43 @@
+package netbox
+
+import "sync"
+
+// tokenPlaintextCache holds the full v2 token plaintext
+// (`nbt_<KEY>.<SECRET>`) captured at create time, keyed by token ID, for
+// ephemeralNetboxTokenPlaintext to hand out. NetBox itself never returns
+// that plaintext again after creation, so this is the only place it can
+// come from; a live UsersTokensRead cannot substitute for it.
+type tokenPlaintextCache struct {
+	mu     sync.Mutex
+	values map[int64]string
+}
+
+func newTokenPlaintextCache() *tokenPlaintextCache {
+	return &tokenPlaintextCache{values: make(map[int64]string)}
+}
+
+// globalPlaintextCache is process-wide rather than a field on providerState
+// because ephemeralNetboxTokenPlaintext is served by a separate
+// plugin-framework provider instance behind the protocol v6 mux (see
+// ProviderServer): it is configured independently of, and never receives a
+// reference to, the *providerState that resourceNetboxTokenCreate runs
+// against. A single cache per process is the only thing both sides can
+// reach.
+var globalPlaintextCache = newTokenPlaintextCache()
+
+// store stashes the plaintext captured for tokenID at create time.
+func (c *tokenPlaintextCache) store(tokenID int64, plaintext string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[tokenID] = plaintext
+}
+
+// consume returns and removes the plaintext stashed for tokenID, if any, so
+// it can only ever be read once.
+func (c *tokenPlaintextCache) consume(tokenID int64) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	plaintext, ok := c.values[tokenID]
+	delete(c.values, tokenID)
+	return plaintext, ok
+}
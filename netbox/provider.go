@@ -0,0 +1,163 @@
+package netbox
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fbreckle/go-netbox/netbox/client"
+	"github.com/fbreckle/go-netbox/netbox/client/users"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerState is the configuration shared by every resource and data
+// source in this provider. It embeds the generated go-netbox API client so
+// that handlers can call api.Users.UsersTokensCreate(...) etc. directly.
+type providerState struct {
+	*client.NetBoxAPI
+	serverURL    string
+	tokenManager TokenManager
+}
+
+// Provider returns a *schema.Provider for NetBox.
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"server_url": {
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_SERVER_URL", nil),
+				Description: "Base URL of the NetBox instance, e.g. `https://netbox.example.com`.",
+			},
+			"api_token": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_API_TOKEN", nil),
+				Description: "API token used to authenticate against NetBox.",
+			},
+			"request_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     30,
+				Description: "Per-request timeout in seconds.",
+			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     3,
+				Description: "Maximum number of retries for requests that fail with a status in retry_on_status.",
+			},
+			"retry_wait_min": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "1s",
+				Description: "Minimum backoff between retries, e.g. `1s`.",
+			},
+			"retry_wait_max": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "30s",
+				Description: "Maximum backoff between retries, e.g. `30s`.",
+			},
+			"retry_on_status": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "HTTP status codes that trigger a retry. Defaults to 429 and every 5xx; 401/403 are always routed to the TokenManager instead, and 404 on read is never retried.",
+			},
+			"managed_token_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "ID of an existing `netbox_token` resource to track for expiration-based refresh. When set, the provider proactively re-reads that token's `expires` timestamp from NetBox as it nears expiry instead of trusting `api_token` for the whole lifetime of a long-running apply. Leave unset to keep the static, non-refreshing behavior.",
+			},
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"netbox_token":       resourceNetboxToken(),
+			"netbox_tokens":      resourceNetboxTokens(),
+			"netbox_token_usage": resourceNetboxTokenUsage(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"netbox_token": dataSourceNetboxToken(),
+		},
+		ConfigureContextFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	serverURL := d.Get("server_url").(string)
+	apiToken := d.Get("api_token").(string)
+
+	retryWaitMin, err := time.ParseDuration(d.Get("retry_wait_min").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	retryWaitMax, err := time.ParseDuration(d.Get("retry_wait_max").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	retryOnStatus := defaultRetryOnStatus
+	if raw := d.Get("retry_on_status").([]interface{}); len(raw) > 0 {
+		retryOnStatus = make([]int, len(raw))
+		for i, v := range raw {
+			retryOnStatus[i] = v.(int)
+		}
+	}
+
+	var tokenManager TokenManager
+	if managedTokenID := int64(d.Get("managed_token_id").(int)); managedTokenID > 0 {
+		// Refresh over a plain transport so re-reading the tracked token's
+		// expiry can never itself trigger the 401/403 retry path below.
+		refreshTransport := httptransport.New(serverURL, client.DefaultBasePath, nil)
+		refreshTransport.DefaultAuthentication = httptransport.APIKeyAuth("Authorization", "header", "Token "+apiToken)
+		refreshClient := client.New(refreshTransport, nil)
+
+		tokenManager = newExpirationTokenManager(managedTokenID, func(ctx context.Context, tokenID int64) (string, time.Time, error) {
+			res, err := refreshClient.Users.UsersTokensRead(users.NewUsersTokensReadParams().WithID(tokenID).WithContext(ctx), nil)
+			if err != nil {
+				return "", time.Time{}, err
+			}
+			payload := res.GetPayload()
+
+			// ALLOW_TOKEN_RETRIEVAL is disabled by default, so Key is
+			// usually blank here; keep using the configured api_token as
+			// the credential and rely on the re-read expires timestamp
+			// purely to time the next refresh.
+			token := apiToken
+			if payload.Key != "" {
+				token = payload.Key
+			}
+			var expires time.Time
+			if payload.Expires != nil {
+				expires = time.Time(*payload.Expires)
+			}
+			return token, expires, nil
+		})
+	} else {
+		tokenManager = newStaticTokenManager(apiToken)
+	}
+
+	transport := httptransport.New(serverURL, client.DefaultBasePath, nil)
+	// DefaultAuthentication only seeds the header for non-HTTP consumers of
+	// this transport (if any); every real request goes through
+	// authTokenTransport below, which overwrites it from tokenManager on
+	// every call so expiration-based refresh is actually on the request
+	// path, not just the 401/403 retry path.
+	transport.DefaultAuthentication = httptransport.APIKeyAuth("Authorization", "header", "Token "+apiToken)
+	if transport.Transport == nil {
+		transport.Transport = http.DefaultTransport
+	}
+	requestTimeout := time.Duration(d.Get("request_timeout").(int)) * time.Second
+	transport.Transport = newTimeoutTransport(transport.Transport, requestTimeout)
+	transport.Transport = newRetryableTransport(transport.Transport, d.Get("max_retries").(int), retryWaitMin, retryWaitMax, retryOnStatus)
+	transport.Transport = &securityExceptionTransport{next: transport.Transport, manager: tokenManager}
+	transport.Transport = &authTokenTransport{next: transport.Transport, manager: tokenManager}
+
+	return &providerState{
+		NetBoxAPI:    client.New(transport, nil),
+		serverURL:    serverURL,
+		tokenManager: tokenManager,
+	}, nil
+}
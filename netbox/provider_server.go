@@ -0,0 +1,77 @@
+package netbox
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// frameworkProvider hosts the parts of this provider that SDKv2 cannot
+// express, namely ephemeral resources. It carries no schema or
+// configuration of its own: server_url/api_token/etc. are configured
+// through the classic SDKv2 provider returned by Provider(), which is a
+// separate provider instance behind the mux with no shared Go state. The
+// ephemeral resources it hosts get what they need from package-level state
+// instead (see globalPlaintextCache).
+type frameworkProvider struct{}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "netbox"
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = providerschema.Schema{}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newEphemeralNetboxTokenPlaintext,
+	}
+}
+
+// ProviderServer returns the protocol v6 server that muxes the classic
+// SDKv2 provider (Provider) together with frameworkProvider, so that
+// netbox_token_plaintext is actually reachable from a `.tf` config. main()
+// should serve this instead of Provider() directly, e.g.:
+//
+//	server, err := netbox.ProviderServer(ctx)
+//	...
+//	tf6server.Serve("registry.terraform.io/atoz-project/netbox", server)
+func ProviderServer(ctx context.Context) (func() tfprotov6.ProviderServer, error) {
+	upgradedSDKProvider, err := tf5to6server.UpgradeServer(ctx, func() tfprotov5.ProviderServer {
+		return schema.NewGRPCProviderServer(Provider())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, []func() tfprotov6.ProviderServer{
+		func() tfprotov6.ProviderServer { return upgradedSDKProvider },
+		providerserver.NewProtocol6(&frameworkProvider{}),
+	}...)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
@@ -2,7 +2,10 @@ package netbox
 
 import (
 	"context"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fbreckle/go-netbox/netbox/client/users"
 	"github.com/fbreckle/go-netbox/netbox/models"
@@ -64,6 +67,33 @@ func resourceNetboxToken() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"rotate_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value (e.g. a timestamp or version string from `terraform_data`) that triggers token rotation whenever it changes. A new token is created for the same `user_id` carrying over `allowed_ips`, `write_enabled`, `description` and `expires`, and the previous token is deleted after `grace_period`.",
+			},
+			"grace_period": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Duration (e.g. `5m`) to keep the previous token alive after a rotation before it is deleted, so in-flight consumers of the old token are not disrupted. Defaults to deleting the previous token immediately.",
+				ValidateFunc: validation.StringMatch(durationRegexp, "must be a valid duration, e.g. '5m' or '1h30m'"),
+			},
+			"previous_token_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the token that was replaced by the most recent rotation, if any.",
+			},
+			"rotated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "RFC3339 timestamp of the most recent rotation, if any.",
+			},
+			"full_key": {
+				Type:        schema.TypeString,
+				Sensitive:   true,
+				Computed:    true,
+				Description: "Full v2 token plaintext (`nbt_<KEY>.<SECRET>`), populated only on the create transition since NetBox never returns it again afterwards. Prefer the `netbox_token_plaintext` ephemeral resource so it is not persisted to state.",
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -71,6 +101,8 @@ func resourceNetboxToken() *schema.Resource {
 	}
 }
 
+var durationRegexp = regexp.MustCompile(`^([0-9]+(ns|us|µs|ms|s|m|h))+$`)
+
 func resourceNetboxTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	api := m.(*providerState)
 	data := models.WritableToken{}
@@ -111,14 +143,39 @@ func resourceNetboxTokenCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	// For v2 tokens, the key returned here is just the public ID part (nbt_xxx),
-	// not the full plaintext. The full token is only available once at creation
-	// time in the NetBox UI response.
-	if res.GetPayload().Key != "" {
-		d.Set("key", res.GetPayload().Key)
+	// On creation the API response's Key is the only time NetBox ever
+	// returns the full v2 plaintext (nbt_<KEY>.<SECRET>); afterwards it only
+	// exposes the public ID portion. Hold on to that full value and set
+	// full_key after Read, which clears it. key itself must never hold the full secret: for
+	// v2 tokens only the "nbt_<KEY>" portion before the dot is safe to
+	// persist to state, matching what NetBox itself returns on every later
+	// read.
+	fullKey := res.GetPayload().Key
+	if fullKey != "" {
+		d.Set("key", publicTokenPortion(fullKey))
+		globalPlaintextCache.store(res.GetPayload().ID, fullKey)
 	}
 
-	return resourceNetboxTokenRead(ctx, d, m)
+	diags := resourceNetboxTokenRead(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+	if fullKey != "" {
+		d.Set("full_key", fullKey)
+	}
+	return diags
+}
+
+// publicTokenPortion returns the portion of a token value that NetBox is
+// willing to expose after creation. v1 tokens are a bare 40-character
+// secret with no public/private split, so the whole value is returned
+// unchanged; v2 tokens are "nbt_<KEY>.<SECRET>", so only "nbt_<KEY>" is
+// returned, discarding the one-time-only secret half.
+func publicTokenPortion(key string) string {
+	if idx := strings.Index(key, "."); idx != -1 {
+		return key[:idx]
+	}
+	return key
 }
 
 func resourceNetboxTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -157,10 +214,19 @@ func resourceNetboxTokenRead(ctx context.Context, d *schema.ResourceData, m inte
 	d.Set("write_enabled", token.WriteEnabled)
 	d.Set("description", token.Description)
 
+	// full_key is a one-shot value only ever populated on the create
+	// transition (see resourceNetboxTokenCreate); clear it on every
+	// subsequent read so it never lingers from a prior apply.
+	d.Set("full_key", "")
+
 	return nil
 }
 
 func resourceNetboxTokenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("rotate_trigger") {
+		return resourceNetboxTokenRotate(ctx, d, m)
+	}
+
 	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableToken{}
@@ -201,6 +267,61 @@ func resourceNetboxTokenUpdate(ctx context.Context, d *schema.ResourceData, m in
 	return resourceNetboxTokenRead(ctx, d, m)
 }
 
+// resourceNetboxTokenRotate creates a fresh token carrying over the current
+// policies (allowed_ips, write_enabled, description, expires) for the same
+// user_id, then retires the previous token after grace_period. This mirrors
+// the Vault provider's vault_token rotation model so that consumers which
+// depend on this resource's stable Terraform address never see it destroyed
+// and recreated.
+func resourceNetboxTokenRotate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+	previousID := d.Id()
+
+	// d.Get("key") currently holds the previous, still-live token's value
+	// (NetBox enforces it as unique), so clear it before minting the
+	// replacement or Create would ask NetBox to reuse that same key and be
+	// rejected as a conflict.
+	d.Set("key", "")
+	if diags := resourceNetboxTokenCreate(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	newID := d.Id()
+	// Create's own trailing Read already clears full_key, so capture the
+	// value it just populated here and restore it after our own trailing
+	// Read below does the same.
+	fullKey := d.Get("full_key").(string)
+
+	gracePeriod := d.Get("grace_period").(string)
+	if gracePeriod != "" {
+		wait, err := time.ParseDuration(gracePeriod)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		time.Sleep(wait)
+	}
+
+	prevID, err := strconv.ParseInt(previousID, 10, 64)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params := users.NewUsersTokensDeleteParams().WithID(prevID)
+	if _, err := api.Users.UsersTokensDelete(params, nil); err != nil {
+		if errresp, ok := err.(*users.UsersTokensDeleteDefault); !ok || errresp.Code() != 404 {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.Set("previous_token_id", previousID)
+	d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
+	d.SetId(newID)
+
+	diags := resourceNetboxTokenRead(ctx, d, m)
+	if !diags.HasError() && fullKey != "" {
+		d.Set("full_key", fullKey)
+	}
+	return diags
+}
+
 func resourceNetboxTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
@@ -0,0 +1,152 @@
+package netbox
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/fbreckle/go-netbox/netbox/client/users"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// defaultUsageSampleWindow is how many last_used observations
+// resourceNetboxTokenUsage keeps in state when sample_window is unset.
+const defaultUsageSampleWindow = 20
+
+// resourceNetboxTokenUsage is a resource rather than the `data
+// "netbox_token_usage"` originally requested, because its whole point is to
+// accumulate a rolling window of last_used observations across refreshes:
+// SDKv2 treats a data source's Computed attributes as NewComputed before
+// every Read, so d.Get("usage_samples") would come back empty on every
+// single refresh instead of returning what a prior plan accumulated.
+// Resources carry their prior state into Read, so the window actually
+// persists.
+//
+// This is a deliberate deviation from the requested shape, not just a
+// naming choice: as a resource, netbox_token_usage now requires an
+// `apply` to start tracking a token (nothing is created on NetBox, but
+// Terraform still needs a Create to put it in state) and is destroyed,
+// i.e. its accumulated usage_samples are discarded, by `terraform destroy`
+// or removing its block - neither of which applies to a data source. See
+// the Description below for the user-facing callout.
+func resourceNetboxTokenUsage() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetboxTokenUsageCreate,
+		ReadContext:   resourceNetboxTokenUsageRead,
+		UpdateContext: resourceNetboxTokenUsageUpdate,
+		DeleteContext: resourceNetboxTokenUsageDelete,
+
+		Description: `:meta:subcategory:Authentication:Tracks last-access information for an existing NetBox API token, backed by the same endpoint as [resource "netbox_token"](token). Polls on every refresh and accumulates a rolling window of ` + "`last_used`" + ` observations in state, so ` + "`terraform plan`" + ` can be used as an audit to surface tokens idle beyond ` + "`stale_after`" + `.
+
+Note: ` + "`last_used`" + ` is only populated by NetBox when ` + "`ALLOW_TOKEN_RETRIEVAL`" + ` or admin scope permits reading it; otherwise it is empty, ` + "`idle_seconds`" + ` is 0, and ` + "`is_stale`" + ` is true whenever ` + "`stale_after`" + ` is set, as for a token that has never been used.
+
+Note: this is a resource, not a data source, even though it creates nothing on NetBox. A data source cannot retain ` + "`usage_samples`" + ` across refreshes (SDKv2 resets a data source's computed attributes before every ` + "`Read`" + `), so tracking a token here requires one ` + "`apply`" + ` to start, and running ` + "`terraform destroy`" + ` (or removing the block) discards the accumulated window instead of merely un-tracking the token.`,
+
+		Schema: map[string]*schema.Schema{
+			"token_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"last_used": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"idle_seconds": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Seconds since last_used, derived at read time.",
+			},
+			"stale_after": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Duration (e.g. `720h`) after which a token with no recorded use is considered stale.",
+				ValidateFunc: validation.StringMatch(durationRegexp, "must be a valid duration, e.g. '720h'"),
+			},
+			"is_stale": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if stale_after is set and the token has gone unused for longer than it (or has never been used).",
+			},
+			"sample_window": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     defaultUsageSampleWindow,
+				Description: "Number of last_used observations to retain in usage_samples.",
+			},
+			"usage_samples": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Rolling window of distinct last_used values observed across successive refreshes, most recent last.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceNetboxTokenUsageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId(strconv.Itoa(d.Get("token_id").(int)))
+	return resourceNetboxTokenUsageRead(ctx, d, m)
+}
+
+func resourceNetboxTokenUsageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+	id := int64(d.Get("token_id").(int))
+
+	res, err := api.Users.UsersTokensRead(users.NewUsersTokensReadParams().WithID(id), nil)
+	if err != nil {
+		if errresp, ok := err.(*users.UsersTokensReadDefault); ok && errresp.Code() == 404 {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	token := res.GetPayload()
+
+	lastUsed := ""
+	if token.LastUsed != nil {
+		lastUsed = token.LastUsed.String()
+	}
+	d.Set("last_used", lastUsed)
+
+	idleSeconds := 0
+	if token.LastUsed != nil {
+		idleSeconds = int(time.Since(time.Time(*token.LastUsed)).Seconds())
+	}
+	d.Set("idle_seconds", idleSeconds)
+
+	isStale := false
+	if staleAfterStr := d.Get("stale_after").(string); staleAfterStr != "" {
+		staleAfter, err := time.ParseDuration(staleAfterStr)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		isStale = token.LastUsed == nil || time.Since(time.Time(*token.LastUsed)) > staleAfter
+	}
+	d.Set("is_stale", isStale)
+
+	samples := d.Get("usage_samples").([]interface{})
+	if lastUsed != "" && (len(samples) == 0 || samples[len(samples)-1].(string) != lastUsed) {
+		samples = append(samples, lastUsed)
+	}
+	if window := d.Get("sample_window").(int); window > 0 && len(samples) > window {
+		samples = samples[len(samples)-window:]
+	}
+	d.Set("usage_samples", samples)
+
+	return nil
+}
+
+func resourceNetboxTokenUsageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceNetboxTokenUsageRead(ctx, d, m)
+}
+
+func resourceNetboxTokenUsageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,459 @@
+package netbox
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/fbreckle/go-netbox/netbox/client/users"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// netboxTokensMaxConcurrency bounds how many UsersTokensCreate/Update/Delete
+// calls resourceNetboxTokens issues in parallel for a single apply.
+const netboxTokensMaxConcurrency = 8
+
+func resourceNetboxTokens() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetboxTokensCreate,
+		ReadContext:   resourceNetboxTokensRead,
+		UpdateContext: resourceNetboxTokensUpdate,
+		DeleteContext: resourceNetboxTokensDelete,
+		CustomizeDiff: resourceNetboxTokensValidateUniqueNames,
+
+		Description: `:meta:subcategory:Authentication:Manages a batch of NetBox API tokens (see [resource "netbox_token"](./token)) as a single Terraform resource. Each entry is keyed by a stable, user-provided ` + "`name`" + `, so adding or removing entries from the list creates or deletes only the affected tokens instead of recreating the whole set. Intended for infrastructures issuing per-service-account tokens at scale, where one-resource-per-token produces thousands of state entries and very slow refreshes.`,
+
+		Schema: map[string]*schema.Schema{
+			"entry": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Stable key identifying this entry across applies. Changing it deletes the old token and creates a new one.",
+						},
+						"user_id": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"allowed_ips": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.IsCIDR,
+							},
+						},
+						"write_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"expires": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"token_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": {
+							Type:      schema.TypeString,
+							Sensitive: true,
+							Computed:  true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceNetboxTokensValidateUniqueNames rejects configs with duplicate
+// entry names: oldByName/seen in resourceNetboxTokensUpdate index purely by
+// name, so duplicates would silently collide and orphan or misattribute
+// tokens between entries.
+func resourceNetboxTokensValidateUniqueNames(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	raw := d.Get("entry").([]interface{})
+	seen := make(map[string]bool, len(raw))
+	for _, v := range raw {
+		name := v.(map[string]interface{})["name"].(string)
+		if name == "" {
+			// Not yet known, e.g. interpolated from a resource that hasn't
+			// applied yet: d.Get reads an unknown value back as "", so
+			// every such entry would otherwise collide here even though
+			// they may resolve to distinct names once applied.
+			continue
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate entry name %q: each entry must have a unique name", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// netboxTokensEntry is the decoded form of one "entry" block.
+type netboxTokensEntry struct {
+	name         string
+	userID       int64
+	description  string
+	allowedIps   []interface{}
+	writeEnabled bool
+	expires      string
+
+	tokenID string
+	key     string
+}
+
+func expandNetboxTokensEntry(raw map[string]interface{}) netboxTokensEntry {
+	return netboxTokensEntry{
+		name:         raw["name"].(string),
+		userID:       int64(raw["user_id"].(int)),
+		description:  raw["description"].(string),
+		allowedIps:   raw["allowed_ips"].([]interface{}),
+		writeEnabled: raw["write_enabled"].(bool),
+		expires:      raw["expires"].(string),
+		tokenID:      raw["token_id"].(string),
+		key:          raw["key"].(string),
+	}
+}
+
+func (e netboxTokensEntry) flatten() map[string]interface{} {
+	return map[string]interface{}{
+		"name":          e.name,
+		"user_id":       int(e.userID),
+		"description":   e.description,
+		"allowed_ips":   e.allowedIps,
+		"write_enabled": e.writeEnabled,
+		"expires":       e.expires,
+		"token_id":      e.tokenID,
+		"key":           e.key,
+	}
+}
+
+// equalConfig reports whether two entries would produce the same
+// WritableToken payload, ignoring the computed token_id/key.
+func (e netboxTokensEntry) equalConfig(other netboxTokensEntry) bool {
+	if e.userID != other.userID || e.description != other.description ||
+		e.writeEnabled != other.writeEnabled || e.expires != other.expires ||
+		len(e.allowedIps) != len(other.allowedIps) {
+		return false
+	}
+	for i := range e.allowedIps {
+		if e.allowedIps[i] != other.allowedIps[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (e netboxTokensEntry) toWritableToken() (*models.WritableToken, error) {
+	data := &models.WritableToken{
+		User:         &e.userID,
+		WriteEnabled: e.writeEnabled,
+		Description:  e.description,
+	}
+	data.AllowedIps = make([]models.IPNetwork, len(e.allowedIps))
+	for i, v := range e.allowedIps {
+		data.AllowedIps[i] = v
+	}
+	if e.expires != "" {
+		expires, err := strfmt.ParseDateTime(e.expires)
+		if err != nil {
+			return nil, err
+		}
+		data.Expires = &expires
+	}
+	return data, nil
+}
+
+// persistNetboxTokensEntries records whichever entries already have a
+// tokenID (i.e. actually exist on the NetBox side) into d, even when the
+// caller is about to return an error for some other entry. Without this, a
+// batch that creates or updates tokens for several entries but fails on one
+// would leave the successful ones out of state entirely, so the next apply
+// would create them again.
+func persistNetboxTokensEntries(d *schema.ResourceData, entries []netboxTokensEntry) {
+	names := make([]string, 0, len(entries))
+	flattened := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		if e.tokenID == "" {
+			continue
+		}
+		names = append(names, e.name)
+		flattened = append(flattened, e.flatten())
+	}
+	if len(flattened) == 0 {
+		return
+	}
+	d.SetId(hashNetboxTokensNames(names))
+	d.Set("entry", flattened)
+}
+
+// runConcurrently runs fn(0)..fn(n-1) with at most maxConcurrency in
+// flight at once, and returns the combined error of every call that failed.
+func runConcurrently(n int, maxConcurrency int, fn func(i int) error) error {
+	sem := make(chan struct{}, maxConcurrency)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func resourceNetboxTokensCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+	raw := d.Get("entry").([]interface{})
+	entries := make([]netboxTokensEntry, len(raw))
+	for i, v := range raw {
+		entries[i] = expandNetboxTokensEntry(v.(map[string]interface{}))
+	}
+
+	err := runConcurrently(len(entries), netboxTokensMaxConcurrency, func(i int) error {
+		data, err := entries[i].toWritableToken()
+		if err != nil {
+			return err
+		}
+		res, err := api.Users.UsersTokensCreate(users.NewUsersTokensCreateParams().WithData(data), nil)
+		if err != nil {
+			return err
+		}
+		entries[i].tokenID = strconv.FormatInt(res.GetPayload().ID, 10)
+		entries[i].key = res.GetPayload().Key
+		return nil
+	})
+	if err != nil {
+		persistNetboxTokensEntries(d, entries)
+		return diag.FromErr(err)
+	}
+
+	names := make([]string, len(entries))
+	flattened := make([]interface{}, len(entries))
+	for i, e := range entries {
+		names[i] = e.name
+		flattened[i] = e.flatten()
+	}
+	d.SetId(hashNetboxTokensNames(names))
+	d.Set("entry", flattened)
+
+	return resourceNetboxTokensRead(ctx, d, m)
+}
+
+func resourceNetboxTokensRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+	raw := d.Get("entry").([]interface{})
+	entries := make([]netboxTokensEntry, len(raw))
+	for i, v := range raw {
+		entries[i] = expandNetboxTokensEntry(v.(map[string]interface{}))
+	}
+
+	missing := make([]bool, len(entries))
+	err := runConcurrently(len(entries), netboxTokensMaxConcurrency, func(i int) error {
+		if entries[i].tokenID == "" {
+			return nil
+		}
+		id, err := strconv.ParseInt(entries[i].tokenID, 10, 64)
+		if err != nil {
+			return err
+		}
+		res, err := api.Users.UsersTokensRead(users.NewUsersTokensReadParams().WithID(id), nil)
+		if err != nil {
+			if errresp, ok := err.(*users.UsersTokensReadDefault); ok && errresp.Code() == 404 {
+				missing[i] = true
+				return nil
+			}
+			return err
+		}
+		token := res.GetPayload()
+		if token.User != nil {
+			entries[i].userID = token.User.ID
+		}
+		if token.Key != "" {
+			entries[i].key = token.Key
+		}
+		entries[i].description = token.Description
+		entries[i].writeEnabled = token.WriteEnabled
+		entries[i].allowedIps = make([]interface{}, len(token.AllowedIps))
+		for j, ip := range token.AllowedIps {
+			entries[i].allowedIps[j] = ip
+		}
+		if token.Expires != nil {
+			entries[i].expires = token.Expires.String()
+		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	flattened := make([]interface{}, 0, len(entries))
+	for i, e := range entries {
+		if missing[i] {
+			continue
+		}
+		flattened = append(flattened, e.flatten())
+	}
+	d.Set("entry", flattened)
+
+	return nil
+}
+
+func resourceNetboxTokensUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+	oldRaw, newRaw := d.GetChange("entry")
+
+	oldByName := map[string]netboxTokensEntry{}
+	for _, v := range oldRaw.([]interface{}) {
+		e := expandNetboxTokensEntry(v.(map[string]interface{}))
+		oldByName[e.name] = e
+	}
+
+	newEntries := make([]netboxTokensEntry, len(newRaw.([]interface{})))
+	for i, v := range newRaw.([]interface{}) {
+		newEntries[i] = expandNetboxTokensEntry(v.(map[string]interface{}))
+	}
+
+	seen := map[string]bool{}
+	err := runConcurrently(len(newEntries), netboxTokensMaxConcurrency, func(i int) error {
+		entry := newEntries[i]
+		old, existed := oldByName[entry.name]
+		if !existed {
+			data, err := entry.toWritableToken()
+			if err != nil {
+				return err
+			}
+			res, err := api.Users.UsersTokensCreate(users.NewUsersTokensCreateParams().WithData(data), nil)
+			if err != nil {
+				return err
+			}
+			newEntries[i].tokenID = strconv.FormatInt(res.GetPayload().ID, 10)
+			newEntries[i].key = res.GetPayload().Key
+			return nil
+		}
+
+		newEntries[i].tokenID = old.tokenID
+		newEntries[i].key = old.key
+		if old.equalConfig(entry) {
+			return nil
+		}
+		id, err := strconv.ParseInt(old.tokenID, 10, 64)
+		if err != nil {
+			return err
+		}
+		data, err := entry.toWritableToken()
+		if err != nil {
+			return err
+		}
+		_, err = api.Users.UsersTokensUpdate(users.NewUsersTokensUpdateParams().WithID(id).WithData(data), nil)
+		return err
+	})
+	if err != nil {
+		persistNetboxTokensEntries(d, newEntries)
+		return diag.FromErr(err)
+	}
+	for _, e := range newEntries {
+		seen[e.name] = true
+	}
+
+	var removed []netboxTokensEntry
+	for name, old := range oldByName {
+		if !seen[name] {
+			removed = append(removed, old)
+		}
+	}
+	err = runConcurrently(len(removed), netboxTokensMaxConcurrency, func(i int) error {
+		id, err := strconv.ParseInt(removed[i].tokenID, 10, 64)
+		if err != nil {
+			return err
+		}
+		_, err = api.Users.UsersTokensDelete(users.NewUsersTokensDeleteParams().WithID(id), nil)
+		if errresp, ok := err.(*users.UsersTokensDeleteDefault); ok && errresp.Code() == 404 {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		persistNetboxTokensEntries(d, newEntries)
+		return diag.FromErr(err)
+	}
+
+	names := make([]string, len(newEntries))
+	flattened := make([]interface{}, len(newEntries))
+	for i, e := range newEntries {
+		names[i] = e.name
+		flattened[i] = e.flatten()
+	}
+	d.SetId(hashNetboxTokensNames(names))
+	d.Set("entry", flattened)
+
+	return resourceNetboxTokensRead(ctx, d, m)
+}
+
+func resourceNetboxTokensDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+	raw := d.Get("entry").([]interface{})
+	entries := make([]netboxTokensEntry, len(raw))
+	for i, v := range raw {
+		entries[i] = expandNetboxTokensEntry(v.(map[string]interface{}))
+	}
+
+	err := runConcurrently(len(entries), netboxTokensMaxConcurrency, func(i int) error {
+		if entries[i].tokenID == "" {
+			return nil
+		}
+		id, err := strconv.ParseInt(entries[i].tokenID, 10, 64)
+		if err != nil {
+			return err
+		}
+		_, err = api.Users.UsersTokensDelete(users.NewUsersTokensDeleteParams().WithID(id), nil)
+		if errresp, ok := err.(*users.UsersTokensDeleteDefault); ok && errresp.Code() == 404 {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// hashNetboxTokensNames derives a stable resource ID from the set of entry
+// names, independent of their order in the list.
+func hashNetboxTokensNames(names []string) string {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	sum := sha256.New()
+	for _, name := range sorted {
+		sum.Write([]byte(name))
+		sum.Write([]byte{0})
+	}
+	return hex.EncodeToString(sum.Sum(nil))
+}
@@ -0,0 +1,189 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenManager mediates how the provider obtains and refreshes the NetBox
+// API token used to authenticate requests. It is modeled on the Neo4j
+// driver's AuthTokenManager design: the provider asks for a token lazily
+// and the manager decides when and how to refresh it, rather than the
+// provider holding a single static credential for its whole lifetime.
+type TokenManager interface {
+	// GetAuthToken returns the token to use for the next request.
+	GetAuthToken(ctx context.Context) (string, error)
+	// OnTokenExpired is called after HandleSecurityException reports the
+	// token as expired; it should obtain and store a fresh token.
+	OnTokenExpired(ctx context.Context, token string) error
+	// HandleSecurityException is called on every NetBox 401/403 response.
+	// It returns true if it handled the error (e.g. by marking the token
+	// expired so the caller should refresh and retry), or false if the
+	// error should be surfaced to the user unchanged.
+	HandleSecurityException(ctx context.Context, err error) (handled bool)
+}
+
+// staticTokenManager implements TokenManager for the common case of a
+// single, long-lived API token configured on the provider block. This is
+// the provider's pre-existing behavior; it never refreshes the token.
+type staticTokenManager struct {
+	token string
+}
+
+func newStaticTokenManager(token string) *staticTokenManager {
+	return &staticTokenManager{token: token}
+}
+
+func (m *staticTokenManager) GetAuthToken(ctx context.Context) (string, error) {
+	return m.token, nil
+}
+
+func (m *staticTokenManager) OnTokenExpired(ctx context.Context, token string) error {
+	return nil
+}
+
+func (m *staticTokenManager) HandleSecurityException(ctx context.Context, err error) bool {
+	return false
+}
+
+// expirationRefreshWindow is how far ahead of a token's expires timestamp
+// expirationTokenManager proactively re-reads it from NetBox.
+const expirationRefreshWindow = 30 * time.Second
+
+// expirationTokenManager watches the `expires` field of a netbox_token
+// resource and proactively re-reads it from NetBox as it nears expiry, so a
+// long-running terraform apply using a short-lived v2 token survives
+// rotation without manual intervention.
+type expirationTokenManager struct {
+	tokenID     int64
+	refreshFunc func(ctx context.Context, tokenID int64) (token string, expires time.Time, err error)
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// newExpirationTokenManager builds an expiration-aware TokenManager for the
+// token identified by tokenID. refreshFunc is expected to re-read the token
+// via UsersTokensRead and report its current value and expiry.
+func newExpirationTokenManager(tokenID int64, refreshFunc func(ctx context.Context, tokenID int64) (string, time.Time, error)) *expirationTokenManager {
+	return &expirationTokenManager{tokenID: tokenID, refreshFunc: refreshFunc}
+}
+
+func (m *expirationTokenManager) GetAuthToken(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token == "" || (!m.expires.IsZero() && time.Until(m.expires) < expirationRefreshWindow) {
+		token, expires, err := m.refreshFunc(ctx, m.tokenID)
+		if err != nil {
+			return "", err
+		}
+		m.token, m.expires = token, expires
+	}
+	return m.token, nil
+}
+
+func (m *expirationTokenManager) OnTokenExpired(ctx context.Context, token string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	newToken, expires, err := m.refreshFunc(ctx, m.tokenID)
+	if err != nil {
+		return err
+	}
+	m.token, m.expires = newToken, expires
+	return nil
+}
+
+func (m *expirationTokenManager) HandleSecurityException(ctx context.Context, err error) bool {
+	m.mu.Lock()
+	m.token = ""
+	m.mu.Unlock()
+	return true
+}
+
+// authTokenTransport sets the Authorization header from manager.GetAuthToken
+// before every request, rather than relying on the static header go-openapi
+// attaches once at client-construction time. Without this,
+// expirationTokenManager's proactive refresh-before-expiry check inside
+// GetAuthToken would only ever run from securityExceptionTransport's
+// reactive 401/403 path, never on the normal request path it's meant to
+// protect.
+type authTokenTransport struct {
+	next    http.RoundTripper
+	manager TokenManager
+}
+
+func (t *authTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.manager.GetAuthToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Token "+token)
+	return t.next.RoundTrip(req)
+}
+
+// securityExceptionTransport wraps an http.RoundTripper and routes NetBox
+// 401/403 responses through the configured TokenManager, retrying the
+// request once with a refreshed token if the manager handled the exception.
+type securityExceptionTransport struct {
+	next    http.RoundTripper
+	manager TokenManager
+}
+
+func (t *securityExceptionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Buffer the body up front, the same way retryableTransport does, so it
+	// can be replayed below: req.Body is fully drained by the first
+	// RoundTrip, and Clone does not restore it.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || t.manager == nil {
+		return resp, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+		return resp, nil
+	}
+
+	ctx := req.Context()
+	if !t.manager.HandleSecurityException(ctx, fmt.Errorf("netbox: request failed with status %d", resp.StatusCode)) {
+		return resp, nil
+	}
+
+	// Per the TokenManager contract, OnTokenExpired is what actually
+	// refreshes the credential; call it once with the token that just failed
+	// and retry with whatever GetAuthToken returns afterwards, rather than
+	// refreshing twice and discarding the first result.
+	expiredToken := strings.TrimPrefix(req.Header.Get("Authorization"), "Token ")
+	if err := t.manager.OnTokenExpired(ctx, expiredToken); err != nil {
+		return resp, nil
+	}
+	token, err := t.manager.GetAuthToken(ctx)
+	if err != nil {
+		return resp, nil
+	}
+
+	retryReq := req.Clone(ctx)
+	if body != nil {
+		retryReq.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	retryReq.Header.Set("Authorization", "Token "+token)
+	resp.Body.Close()
+	return t.next.RoundTrip(retryReq)
+}
@@ -0,0 +1,150 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// timeoutTransport bounds how long a single attempt, including reading its
+// response body, may take. retryableTransport sits further out in the chain,
+// so each retry it performs gets a fresh timeout of its own.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func newTimeoutTransport(next http.RoundTripper, timeout time.Duration) *timeoutTransport {
+	return &timeoutTransport{next: next, timeout: timeout}
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	defer cancel()
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		return resp, err
+	}
+	// Read the body while ctx is still live: cancel runs on return, and a
+	// later read of the body would fail with context.Canceled.
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// defaultRetryOnStatus is the set of HTTP status codes retryableTransport
+// retries by default: 429 and every 5xx. 401/403 are deliberately excluded
+// here since they are routed to the TokenManager via
+// securityExceptionTransport instead, and 404 on read is passed through
+// unchanged.
+var defaultRetryOnStatus = func() []int {
+	codes := []int{429}
+	for code := 500; code <= 599; code++ {
+		codes = append(codes, code)
+	}
+	return codes
+}()
+
+// retryableTransport wraps an http.RoundTripper and retries requests whose
+// response status is in retryOnStatus, using exponential backoff with full
+// jitter between attempts. It is similar in spirit to
+// hashicorp/go-retryablehttp's CheckRetry, scoped down to what the NetBox
+// client needs.
+type retryableTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+	retryOn    map[int]bool
+}
+
+func newRetryableTransport(next http.RoundTripper, maxRetries int, waitMin, waitMax time.Duration, retryOnStatus []int) *retryableTransport {
+	retryOn := make(map[int]bool, len(retryOnStatus))
+	for _, code := range retryOnStatus {
+		retryOn[code] = true
+	}
+	return &retryableTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		waitMin:    waitMin,
+		waitMax:    waitMax,
+		retryOn:    retryOn,
+	}
+}
+
+func (t *retryableTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err = t.next.RoundTrip(req)
+		if err != nil || !t.shouldRetry(req, resp) || attempt >= t.maxRetries {
+			return resp, err
+		}
+		if resp.Body != nil {
+			resp.Body.Close()
+		}
+
+		wait := t.backoff(attempt)
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return resp, req.Context().Err()
+		}
+	}
+}
+
+// shouldRetry never retries 401/403, which securityExceptionTransport routes
+// to the TokenManager, nor a 404 on a GET, even if retryOn lists them.
+func (t *retryableTransport) shouldRetry(req *http.Request, resp *http.Response) bool {
+	if resp == nil {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false
+	case http.StatusNotFound:
+		if req.Method == http.MethodGet {
+			return false
+		}
+	}
+	return t.retryOn[resp.StatusCode]
+}
+
+// backoff returns an exponential delay for the given attempt (0-indexed),
+// capped at waitMax, and picks a random wait between waitMin and that delay
+// so concurrent retries from many resources don't all land at once. The
+// first retry (attempt 0) always waits exactly waitMin.
+func (t *retryableTransport) backoff(attempt int) time.Duration {
+	wait := t.waitMin << uint(attempt)
+	if wait <= 0 || wait > t.waitMax {
+		wait = t.waitMax
+	}
+	if wait <= t.waitMin {
+		return t.waitMin
+	}
+	return t.waitMin + time.Duration(rand.Int63n(int64(wait-t.waitMin)))
+}